@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type (
@@ -18,26 +20,113 @@ type (
 	}
 
 	// DefaultBinder is the default implementation of the Binder interface.
-	DefaultBinder struct{}
+	DefaultBinder struct {
+		// TimeFormats lists the layouts tried, in order, when binding a time.Time/*time.Time
+		// field that has no `time_format` tag. When set, these are tried before the
+		// Unix-timestamp auto-detect heuristic, so a purely-numeric custom format isn't shadowed
+		// by it. Defaults to defaultTimeFormats (RFC3339 and RFC3339Nano) when left nil, in which
+		// case the Unix-timestamp heuristic is tried first.
+		TimeFormats []string
+		// DefaultLocation is used to interpret a parsed time when the field has no
+		// `time_location` tag and isn't tagged `time_utc:"true"`. Defaults to time.Local when nil.
+		DefaultLocation *time.Location
+
+		customTypesMu sync.RWMutex
+		customTypes   map[reflect.Type]func(values []string, target reflect.Value) error
+	}
 
 	// BindUnmarshaler is the interface used to wrap the UnmarshalParam method.
 	BindUnmarshaler interface {
 		// UnmarshalParam decodes and assigns a value from an form or query param.
 		UnmarshalParam(param string) error
 	}
+
+	// BindingError represents an error that occurred while binding the request data to a struct
+	// field and carries enough context (the field name and the raw values it was bound from) for
+	// callers to build a useful validation/error response.
+	BindingError struct {
+		// Field is the field name where binding failed.
+		Field string `json:"field"`
+		// Values is the raw value(s) that failed to bind.
+		Values []string `json:"-"`
+		*HTTPError
+	}
 )
 
-// Bind implements the `Binder#Bind` function.
-func (b *DefaultBinder) Bind(i interface{}, c Context) (err error) {
+// NewBindingError creates a new instance of BindingError
+func NewBindingError(sourceParam string, values []string, message interface{}, internalError error) error {
+	return &BindingError{
+		Field:  sourceParam,
+		Values: values,
+		HTTPError: &HTTPError{
+			Code:     http.StatusBadRequest,
+			Message:  message,
+			Internal: internalError,
+		},
+	}
+}
+
+// Error implements the `error` interface, delegating to the wrapped HTTPError and, when an
+// internal error is set, appending the field that failed to bind.
+func (be *BindingError) Error() string {
+	if be.Internal != nil {
+		return fmt.Sprintf("%s, field=%s", be.HTTPError.Error(), be.Field)
+	}
+	return be.HTTPError.Error()
+}
+
+// BindPathParams binds path params to bindable object
+func (b *DefaultBinder) BindPathParams(c Context, i interface{}) error {
+	names := c.ParamNames()
+	values := c.ParamValues()
+	params := map[string][]string{}
+	for i, name := range names {
+		params[name] = []string{values[i]}
+	}
+	if err := b.bindData(i, params, "param"); err != nil {
+		return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+// BindQueryParams binds query params to bindable object
+func (b *DefaultBinder) BindQueryParams(c Context, i interface{}) error {
+	if err := b.bindData(i, c.QueryParams(), "query"); err != nil {
+		return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+// BindHeaders binds HTTP headers to a bindable object
+func (b *DefaultBinder) BindHeaders(c Context, i interface{}) error {
+	if err := b.bindData(i, c.Request().Header, "header"); err != nil {
+		return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+// bindCookies binds the request cookies to a bindable object, matching fields tagged `cookie:"..."`.
+func (b *DefaultBinder) bindCookies(c Context, i interface{}) error {
+	cookies := c.Cookies()
+	values := make(map[string][]string, len(cookies))
+	for _, cookie := range cookies {
+		values[cookie.Name] = append(values[cookie.Name], cookie.Value)
+	}
+	if err := b.bindData(i, values, "cookie"); err != nil {
+		return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+// BindBody binds request body contents to bindable object
+// NB: then binding forms take note that this implementation uses standard library form parsing
+// which parses form data from BOTH URL and BODY if content type is not MIMEMultipartForm
+// See non-MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseForm
+// See MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseMultipartForm
+func (b *DefaultBinder) BindBody(c Context, i interface{}) (err error) {
 	req := c.Request()
 	if req.ContentLength == 0 {
-		if req.Method == http.MethodGet || req.Method == http.MethodDelete {
-			if err = b.bindData(i, c.QueryParams(), "query"); err != nil {
-				return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
-			}
-			return
-		}
-		return NewHTTPError(http.StatusBadRequest, "Request body can't be empty")
+		return
 	}
 
 	ctype := req.Header.Get(HeaderContentType)
@@ -74,74 +163,315 @@ func (b *DefaultBinder) Bind(i interface{}, c Context) (err error) {
 	default:
 		return ErrUnsupportedMediaType
 	}
-	return
+	return nil
 }
 
+// Bind implements the `Binder#Bind` function.
+// Binding is done in a specific order: path params, query params (for GET, DELETE and HEAD
+// requests only), headers, cookies and finally the request body, each source overriding fields touched by the ones before it so the body
+// always wins when a field is addressable from more than one source. A field only falls back to
+// matching its bare Go name for a source it has no tag for when it also carries no tag (including
+// a plain `json`/`xml` struct tag) pinning it to a different source; tag the field explicitly for
+// the source it belongs to once more than one source is in play.
+func (b *DefaultBinder) Bind(i interface{}, c Context) (err error) {
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
 
+	// Only bind query parameters for GET/DELETE/HEAD to avoid unexpected behavior with destination struct binding from body.
+	// For example a request URL `&id=1&lang=en` with body `{"id":100,"lang":"de"}` would lead to precedence issues.
+	method := c.Request().Method
+	if method == http.MethodGet || method == http.MethodDelete || method == http.MethodHead {
+		if err = b.BindQueryParams(c, i); err != nil {
+			return err
+		}
+	}
 
-func (b *DefaultBinder) bindData(ptr interface{}, data map[string][]string, tag string) error {
+	if err = b.BindHeaders(c, i); err != nil {
+		return err
+	}
 
-	// 获取指针变量的反射对象时，可以通过 reflect.Elem() 方法获取这个指针指向的元素类型。
-	// 这个获取过程被称为取元素，等效于对指针类型变量做了一个*操作。
+	if err = b.bindCookies(c, i); err != nil {
+		return err
+	}
 
+	return b.BindBody(c, i)
+}
 
+// BindAndValidate binds the request into the given struct using `Bind` and then runs the
+// Validator registered on the Echo instance over the bound struct via `Context#Validate`; with no
+// Validator registered, the error Validate reports for that is handled like any other. It returns the
+// binding error unchanged; a validation failure is returned as-is if it's already an `*HTTPError`
+// or a `*BindingError` (so a Validator that reports per-field failures via BindingError keeps its
+// Field/Values intact) and otherwise wrapped in a plain `*HTTPError` with a 400 status, since
+// Echo's default error handler only special-cases `*HTTPError` and would otherwise turn e.g. a
+// `validator.ValidationErrors` into a 500.
+func (b *DefaultBinder) BindAndValidate(i interface{}, c Context) error {
+	if err := b.Bind(i, c); err != nil {
+		return err
+	}
+	if err := c.Validate(i); err != nil {
+		switch err.(type) {
+		case *HTTPError, *BindingError:
+			return err
+		}
+		return NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
 
-	typ := reflect.TypeOf(ptr).Elem()  	//变量类型
+// RegisterType teaches DefaultBinder how to bind raw values into t, for types it can't own and
+// so can't implement BindUnmarshaler on directly (uuid.UUID, decimal.Decimal, net.IP, a
+// generated protobuf enum, ...). fn receives every raw value bound to the field - typically a
+// single element, but more than one for a repeated query/form/header key - and target, which is
+// always addressable and of type t. Registering the same type again replaces its decoder.
+//
+// The registry is consulted by setWithProperType and unmarshalField before they fall through to
+// the built-in reflect.Kind switch, and is checked both for a field's own type and, for slice
+// fields, its element type. A struct-typed field with no tag for the source being bound is
+// flattened into its own fields when the decoder plan is built, so the registry is not
+// consulted for that field's type.
+func (b *DefaultBinder) RegisterType(t reflect.Type, fn func(values []string, target reflect.Value) error) {
+	b.customTypesMu.Lock()
+	defer b.customTypesMu.Unlock()
+	if b.customTypes == nil {
+		b.customTypes = map[reflect.Type]func(values []string, target reflect.Value) error{}
+	}
+	b.customTypes[t] = fn
+}
 
+func (b *DefaultBinder) customTypeDecoder(t reflect.Type) (func(values []string, target reflect.Value) error, bool) {
+	b.customTypesMu.RLock()
+	defer b.customTypesMu.RUnlock()
+	fn, ok := b.customTypes[t]
+	return fn, ok
+}
 
-	// reflect.Value 是通过 reflect.ValueOf(x) 获得的，只有当X是指针的时候，才可以通过reflec.Value修改实际变量 x 的值。
-	// 由于传入的是指针，需要用 p.Elem() 获取所指向的 v；v.CantSet()输出的是true时便可以用 v.SetFloat() 修改 x 的值。
-	val := reflect.ValueOf(ptr).Elem()
+// timeType, timePtrType and durationType are compared against by reflect.Type equality when
+// building a decoderPlan, which is cheaper than a type assertion on every bound field.
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	timePtrType  = reflect.PtrTo(timeType)
+	durationType = reflect.TypeOf(time.Duration(0))
+)
 
+// defaultTimeFormats are tried, in order, for a time.Time/*time.Time field that has no
+// `time_format` tag and whose value didn't parse as a Unix timestamp.
+var defaultTimeFormats = []string{time.RFC3339, time.RFC3339Nano}
 
-	// Kind() 返回 rtype.kind，描述一种基础类型
-	if typ.Kind() != reflect.Struct {
-		return errors.New("binding element must be a struct")
+type (
+	// decoderField describes, for one struct field reachable from a bindable type, everything
+	// bindData needs in order to set it: where it lives (index, following promoted/nested
+	// structs), what tag name addresses it, and how its value should be decoded. It is computed
+	// once per (reflect.Type, tag) pair and cached in bindDecoderCache.
+	decoderField struct {
+		name           string // tag-resolved (or field) name data is looked up by
+		index          []int  // field index path, as accepted by reflect.Value.FieldByIndex
+		kind           reflect.Kind
+		isSlice        bool
+		sliceKind      reflect.Kind
+		isStructSlice  bool
+		structElemType reflect.Type
+		isMap          bool
+		mapElemKind    reflect.Kind
+		isUnmarshaler  bool
+		isTime         bool
+		isTimePtr      bool
+		timeFormat     string
+		timeUTC        bool
+		timeLocation   string
+		isDuration     bool
 	}
 
+	// decoderPlan is the flattened list of decoderFields for a bindable struct type; nested
+	// (anonymous-tag-less) structs are flattened into their parent's plan at build time so that
+	// bindData only ever has to iterate a single, flat slice.
+	decoderPlan []decoderField
 
-	for i := 0; i < typ.NumField(); i++ {
+	decoderPlanKey struct {
+		typ reflect.Type
+		tag string
+	}
+)
 
-		// reflect.StructField: 反射获取结构体字段的元信息，例如：字段名称、Tags 等
-		typeField := typ.Field(i)
+// bindDecoderCache caches decoderPlans per (struct type, tag) pair so that repeated binds of the
+// same destination type only pay the cost of walking reflect.StructField/Tag.Get once, rather
+// than on every request.
+var bindDecoderCache sync.Map // map[decoderPlanKey]decoderPlan
+
+func decoderPlanFor(typ reflect.Type, tag string) (decoderPlan, error) {
+	key := decoderPlanKey{typ: typ, tag: tag}
+	if cached, ok := bindDecoderCache.Load(key); ok {
+		return cached.(decoderPlan), nil
+	}
+
+	plan, err := buildDecoderPlan(typ, tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	bindDecoderCache.Store(key, plan)
+	return plan, nil
+}
+
+// pinnedBindTagKeys are the tag keys that, when present on a field, pin that field's binding
+// source explicitly. They're consulted by hasOtherPinnedBindTag so that a field tagged for one
+// source doesn't also fall back to its bare Go name when a *different* source's plan is built -
+// e.g. a field tagged only `json:"name"` is meant for the request body and must not additionally
+// be populated by a same-named path param/query param/header/cookie, and a field tagged only
+// `query:"id"` must not answer to a header with the same Go field name either.
+var pinnedBindTagKeys = []string{"param", "query", "header", "cookie", "form", "json", "xml"}
+
+// hasOtherPinnedBindTag reports whether typeField carries a tag, other than tag itself, from
+// pinnedBindTagKeys.
+func hasOtherPinnedBindTag(typeField reflect.StructField, tag string) bool {
+	for _, key := range pinnedBindTagKeys {
+		if key == tag {
+			continue
+		}
+		if typeField.Tag.Get(key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDecoderPlan walks typ's fields once, recursing into tag-less nested structs and flattening
+// their fields into the returned plan under the combined index path.
+func buildDecoderPlan(typ reflect.Type, tag string, parentIndex []int) (decoderPlan, error) {
+	// A throwaway, addressable zero value of typ is enough to evaluate CanSet (depends only on
+	// whether the field is exported) and to probe BindUnmarshaler (depends only on the field's
+	// type) for every field, without needing a real struct instance.
+	dummy := reflect.New(typ).Elem()
 
-		// reflect.value: 反射获取&修改字段值
-		structField := val.Field(i) //字段值
+	var plan decoderPlan
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := dummy.Field(i)
 		if !structField.CanSet() {
 			continue
 		}
 
-		// 获取 reflect.value 的基础类型（非定义的静态类型）
-		structFieldKind := structField.Kind()
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+
+		_, isUnmarshaler := bindUnmarshaler(structField)
 
-		// 获取 reflect.StructField 字段的指定 tag
 		inputFieldName := typeField.Tag.Get(tag)
 		if inputFieldName == "" {
-			// 如果tag为空，就用字段名来表示
+			// A field pinned to a different source (including a plain `json`/`xml` struct tag,
+			// since BindBody decodes those formats straight against the destination type rather
+			// than through bindData) doesn't fall back to its bare Go name here, so it can't be
+			// silently populated from this source too.
+			if hasOtherPinnedBindTag(typeField, tag) {
+				continue
+			}
 			inputFieldName = typeField.Name
-			// 如果tag为空，检查该字段是否是嵌套结构体
-			if _, ok := bindUnmarshaler(structField); !ok {
-				//判断是否是嵌套结构体
-				if structFieldKind == reflect.Struct { 
-					//递归调用，
-					if err := b.bindData(structField.Addr().Interface(), data, tag); err != nil {
-						return err
-					}
-					continue
+			if !isUnmarshaler && structField.Kind() == reflect.Struct {
+				nested, err := buildDecoderPlan(typeField.Type, tag, index)
+				if err != nil {
+					return nil, err
 				}
+				plan = append(plan, nested...)
+				continue
+			}
+		}
+
+		field := decoderField{
+			name:          inputFieldName,
+			index:         index,
+			kind:          typeField.Type.Kind(),
+			isUnmarshaler: isUnmarshaler,
+		}
+		switch field.kind {
+		case reflect.Slice:
+			elemType := typeField.Type.Elem()
+			_, elemIsUnmarshaler := bindUnmarshaler(reflect.New(elemType).Elem())
+			if elemType.Kind() == reflect.Struct && elemType != timeType && !elemIsUnmarshaler {
+				field.isStructSlice = true
+				field.structElemType = elemType
+			} else {
+				field.isSlice = true
+				field.sliceKind = elemType.Kind()
 			}
+		case reflect.Map:
+			field.isMap = true
+			field.mapElemKind = typeField.Type.Elem().Kind()
+		}
+		switch typeField.Type {
+		case timeType:
+			field.isTime = true
+		case timePtrType:
+			field.isTime = true
+			field.isTimePtr = true
+		case durationType:
+			field.isDuration = true
 		}
+		if field.isTime {
+			field.timeFormat = typeField.Tag.Get("time_format")
+			field.timeUTC, _ = strconv.ParseBool(typeField.Tag.Get("time_utc"))
+			field.timeLocation = typeField.Tag.Get("time_location")
+		}
+
+		plan = append(plan, field)
+	}
+
+	return plan, nil
+}
+
+func (b *DefaultBinder) bindData(ptr interface{}, data map[string][]string, tag string) error {
+	typ := reflect.TypeOf(ptr).Elem()
+	val := reflect.ValueOf(ptr).Elem()
 
+	if typ.Kind() != reflect.Struct {
+		return NewBindingError("", nil, "binding element must be a struct", nil)
+	}
 
+	plan, err := decoderPlanFor(typ, tag)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range plan {
+		structField := val.FieldByIndex(field.index)
+
+		if field.isMap {
+			if err := b.bindMapField(data, field, structField); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.isStructSlice {
+			if _, ok := b.customTypeDecoder(field.structElemType); !ok {
+				if err := b.bindStructSliceField(data, field, structField, tag); err != nil {
+					return err
+				}
+				continue
+			}
+			// A type registered via RegisterType always wins over the struct-slice dispatch
+			// above, for the same reason the bare-struct-field registry re-check below does:
+			// the decoderPlan is cached purely by Kind/Type shape and knows nothing about the
+			// registry. Fall through to the generic per-element slice path, which consults the
+			// registry for each element via setWithProperType -> unmarshalField.
+			field.isSlice = true
+			field.sliceKind = reflect.Struct
+		}
+
+		inputFieldName := field.name
 		inputValue, exists := data[inputFieldName]
+		if !exists && field.isSlice {
+			inputValue, exists = data[inputFieldName+"[]"]
+		}
 		if !exists {
 			// Go json.Unmarshal supports case insensitive binding.  However the
 			// url params are bound case sensitive which is inconsistent.  To
 			// fix this we must check all of the map values in a
 			// case-insensitive search.
-			inputFieldName = strings.ToLower(inputFieldName)
+			lowerFieldName := strings.ToLower(inputFieldName)
 			for k, v := range data {
-				if strings.ToLower(k) == inputFieldName {
+				k := strings.TrimSuffix(k, "[]")
+				if strings.ToLower(k) == lowerFieldName {
 					inputValue = v
 					exists = true
 					break
@@ -153,35 +483,165 @@ func (b *DefaultBinder) bindData(ptr interface{}, data map[string][]string, tag
 			continue
 		}
 
-
 		// Call this first, in case we're dealing with an alias to an array type
-		if ok, err := unmarshalField(typeField.Type.Kind(), inputValue[0], structField); ok {
-			if err != nil {
-				return err
+		if field.isUnmarshaler {
+			if ok, err := b.unmarshalField(field.kind, inputValue[0], structField); ok {
+				if err != nil {
+					return NewBindingError(inputFieldName, inputValue, "unmarshalable model", err)
+				}
+				continue
+			}
+		}
+
+		// A type registered via RegisterType always wins over the generic Kind-based dispatch
+		// below, which would otherwise misclassify e.g. a net.IP (Kind Slice) as a plain
+		// []byte-like scalar slice, or hand any other registered type only its first raw value. The
+		// decoderPlan is cached purely by Kind/Type shape and knows nothing about the registry,
+		// so this has to be re-checked per bind rather than baked into the plan.
+		if fn, ok := b.customTypeDecoder(structField.Type()); ok {
+			if err := fn(inputValue, structField); err != nil {
+				return NewBindingError(inputFieldName, inputValue, fmt.Sprintf("unable to decode %s", structField.Type()), err)
 			}
 			continue
 		}
 
 		numElems := len(inputValue)
-		if structFieldKind == reflect.Slice && numElems > 0 {
-			//切片元素类型
-			sliceOf := structField.Type().Elem().Kind()
-			//创建切片
+		if field.isSlice && numElems > 0 {
 			slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
-			//切片逐元素赋值
 			for j := 0; j < numElems; j++ {
-				if err := setWithProperType(sliceOf, inputValue[j], slice.Index(j)); err != nil {
-					return err
+				if err := b.setWithProperType(field.sliceKind, inputValue[j], slice.Index(j)); err != nil {
+					return NewBindingError(inputFieldName, inputValue, fmt.Sprintf("slice with incompatible type, field type=%s", field.sliceKind), err)
 				}
 			}
-			//变量赋值
-			val.Field(i).Set(slice)
-		} else if _, isTime := structField.Interface().(time.Time); isTime {
-			return setTimeField(inputValue, *typeField, *structField)
-		} else if err := setWithProperType(typeField.Type.Kind(), inputValue[0], structField); err != nil {
+			structField.Set(slice)
+		} else if field.isTime {
+			if err := b.setTimeField(inputValue[0], field, structField); err != nil {
+				return NewBindingError(inputFieldName, inputValue, "unable to parse date", err)
+			}
+		} else if field.isDuration {
+			if err := setDurationField(inputValue[0], structField); err != nil {
+				return NewBindingError(inputFieldName, inputValue, "unable to parse duration", err)
+			}
+		} else if err := b.setWithProperType(field.kind, inputValue[0], structField); err != nil {
+			return NewBindingError(inputFieldName, inputValue, fmt.Sprintf("unable to parse '%s' as %s", inputValue[0], field.kind), err)
+		}
+	}
+	return nil
+}
+
+// Beyond plain `name=value` pairs, bindData understands the following bracketed/dotted key
+// syntax for addressing into map and []struct fields, mirroring how nested JSON binds today:
+//
+//	name[key]=value        -> map[string]T field `name`, entry "key"
+//	name[idx].field=value  -> []struct field `name`, index idx, struct field tagged `field`
+//	name[idx][field]=value -> equivalent bracketed form of the above
+//	name[]=value           -> repeated key for scalar slice field `name` (alias for name=value)
+//
+// Keys that don't cleanly parse into one of these shapes for a matching map/slice-of-struct
+// field (e.g. a second bracket level on a map field, or a non-numeric slice index) are rejected
+// with a BindingError rather than silently dropped. The `name[` prefix is matched
+// case-insensitively, mirroring the case-insensitive fallback bindData itself applies to plain
+// `name=value` keys, so e.g. `Meta[key]=v` still reaches a field tagged `form:"meta"`.
+
+// hasFoldPrefix reports whether k starts with prefix, ignoring case.
+func hasFoldPrefix(k, prefix string) bool {
+	return len(k) >= len(prefix) && strings.EqualFold(k[:len(prefix)], prefix)
+}
+
+// bindMapField populates a map[string]T field from bracketed keys of the form `name[key]=value`.
+func (b *DefaultBinder) bindMapField(data map[string][]string, field decoderField, structField reflect.Value) error {
+	prefix := field.name + "["
+	var keys map[string]string
+	for k, v := range data {
+		if !hasFoldPrefix(k, prefix) || len(v) == 0 {
+			continue
+		}
+		mapKey := ""
+		if strings.HasSuffix(k, "]") {
+			mapKey = k[len(prefix) : len(k)-1]
+		}
+		if mapKey == "" || strings.ContainsAny(mapKey, "[].") {
+			return NewBindingError(k, v, fmt.Sprintf("ambiguous key %q for map field %q", k, field.name), nil)
+		}
+		if keys == nil {
+			keys = map[string]string{}
+		}
+		keys[mapKey] = v[0]
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	m := reflect.MakeMapWithSize(structField.Type(), len(keys))
+	for mapKey, value := range keys {
+		elem := reflect.New(structField.Type().Elem()).Elem()
+		if err := b.setWithProperType(field.mapElemKind, value, elem); err != nil {
+			return NewBindingError(field.name+"["+mapKey+"]", []string{value}, fmt.Sprintf("unable to parse '%s' as %s", value, field.mapElemKind), err)
+		}
+		m.SetMapIndex(reflect.ValueOf(mapKey), elem)
+	}
+	structField.Set(m)
+	return nil
+}
+
+// maxStructSliceIndex bounds the highest `name[idx]` index bindStructSliceField will honor, so
+// that a client can't force an allocation of an arbitrarily large slice by sending a single huge
+// index (`items[999999999].name=x`).
+const maxStructSliceIndex = 10000
+
+// bindStructSliceField populates a []struct field from indexed keys of the form
+// `name[idx].field=value` or `name[idx][field]=value`, binding each index's fields via a
+// recursive bindData call so the element struct gets the same tag-driven treatment as any
+// other bindable struct.
+func (b *DefaultBinder) bindStructSliceField(data map[string][]string, field decoderField, structField reflect.Value, tag string) error {
+	prefix := field.name + "["
+	elemData := map[int]map[string][]string{}
+	maxIndex := -1
+	for k, v := range data {
+		if !hasFoldPrefix(k, prefix) {
+			continue
+		}
+		rest := k[len(prefix):]
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return NewBindingError(k, v, fmt.Sprintf("ambiguous key %q for slice field %q", k, field.name), nil)
+		}
+		idx, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return NewBindingError(k, v, fmt.Sprintf("non-numeric index %q for slice field %q", rest[:end], field.name), err)
+		}
+		if idx < 0 || idx > maxStructSliceIndex {
+			return NewBindingError(k, v, fmt.Sprintf("index %d for slice field %q is out of the allowed range [0, %d]", idx, field.name, maxStructSliceIndex), nil)
+		}
+
+		sub := strings.TrimPrefix(rest[end+1:], ".")
+		sub = strings.TrimPrefix(sub, "[")
+		sub = strings.TrimSuffix(sub, "]")
+		if sub == "" || strings.ContainsAny(sub, "[].") {
+			return NewBindingError(k, v, fmt.Sprintf("ambiguous key %q for slice field %q", k, field.name), nil)
+		}
+
+		if elemData[idx] == nil {
+			elemData[idx] = map[string][]string{}
+		}
+		elemData[idx][sub] = v
+		if idx > maxIndex {
+			maxIndex = idx
+		}
+	}
+	if maxIndex < 0 {
+		return nil
+	}
+
+	slice := reflect.MakeSlice(structField.Type(), maxIndex+1, maxIndex+1)
+	for idx, values := range elemData {
+		elem := reflect.New(field.structElemType)
+		if err := b.bindData(elem.Interface(), values, tag); err != nil {
 			return err
 		}
+		slice.Index(idx).Set(elem.Elem())
 	}
+	structField.Set(slice)
 	return nil
 }
 
@@ -193,11 +653,11 @@ func (b *DefaultBinder) bindData(ptr interface{}, data map[string][]string, tag
 
 //获取 reflect.Kind 对应的golang基础类型关系，以便进行类型转换
 
-func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
+func (b *DefaultBinder) setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
 	
 
 	// But also call it here, in case we're dealing with an array of BindUnmarshalers
-	if ok, err := unmarshalField(valueKind, val, structField); ok {
+	if ok, err := b.unmarshalField(valueKind, val, structField); ok {
 		return err
 	}
 
@@ -209,7 +669,7 @@ func setWithProperType(valueKind reflect.Kind, val string, structField reflect.V
 			structField.Set(reflect.New(structField.Type().Elem()))
 		}
 		//如果值是指针类型，则获取其指向的值类型structField.Elem().Kind()和值对象structField.Elem()，然后执行写入val
-		return setWithProperType(structField.Elem().Kind(), val, structField.Elem())
+		return b.setWithProperType(structField.Elem().Kind(), val, structField.Elem())
 
 	case reflect.Int:
 		return setIntField(val, 0, structField)
@@ -248,10 +708,14 @@ func setWithProperType(valueKind reflect.Kind, val string, structField reflect.V
 
 
 
-func unmarshalField(valueKind reflect.Kind, val string, field reflect.Value) (bool, error) {
+func (b *DefaultBinder) unmarshalField(valueKind reflect.Kind, val string, field reflect.Value) (bool, error) {
+	if fn, ok := b.customTypeDecoder(field.Type()); ok {
+		return true, fn([]string{val}, field)
+	}
+
 	switch valueKind {
 	case reflect.Ptr:
-		return unmarshalFieldPtr(val, field)
+		return b.unmarshalFieldPtr(val, field)
 	default:
 		return unmarshalFieldNonPtr(val, field)
 	}
@@ -290,12 +754,12 @@ func unmarshalFieldNonPtr(value string, field reflect.Value) (bool, error) {
 }
 
 
-func unmarshalFieldPtr(value string, field reflect.Value) (bool, error) {
+func (b *DefaultBinder) unmarshalFieldPtr(value string, field reflect.Value) (bool, error) {
 	if field.IsNil() {
 		// Initialize the pointer to a nil value
 		field.Set(reflect.New(field.Type().Elem()))
 	}
-	return unmarshalFieldNonPtr(value, field.Elem())
+	return b.unmarshalField(field.Elem().Kind(), value, field.Elem())
 }
 
 
@@ -344,37 +808,117 @@ func setFloatField(value string, bitSize int, field reflect.Value) error {
 	return err
 }
 
-func setTimeField(value string, structField reflect.StructField, field reflect.Value) error {
-	timeFormat := structField.Tag.Get("time_format")
-	if timeFormat == "" {
-		return errors.New("Blank time format")
-	}
-
+// setTimeField binds value into a time.Time (or, when field.isTimePtr, a *time.Time) field. A
+// field-level `time_format` tag is used as-is. Otherwise, if b.TimeFormats is explicitly
+// configured, those formats are tried first (so a purely-numeric custom format isn't shadowed by
+// the Unix-timestamp heuristic below), then the value is tried as a Unix timestamp (seconds, or
+// milliseconds when its magnitude implies it), falling back to defaultTimeFormats when
+// b.TimeFormats is unset.
+func (b *DefaultBinder) setTimeField(value string, field decoderField, structField reflect.Value) error {
 	if value == "" {
-		field.Set(reflect.ValueOf(time.Time{}))
+		// Leave a *time.Time field nil rather than allocating it just to hold the zero time, so
+		// an empty value still reads as "absent" to callers relying on the pointer-means-optional
+		// contract.
+		if !field.isTimePtr {
+			structField.Set(reflect.ValueOf(time.Time{}))
+		}
 		return nil
 	}
 
+	target := structField
+	if field.isTimePtr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
 
-	l := time.Local
-	if isUTC, _ := strconv.ParseBool(structField.Tag.Get("time_utc")); isUTC {
-		l = time.UTC
+	loc := b.DefaultLocation
+	if loc == nil {
+		loc = time.Local
+	}
+	if field.timeUTC {
+		loc = time.UTC
+	}
+	if field.timeLocation != "" {
+		l, err := time.LoadLocation(field.timeLocation)
+		if err != nil {
+			return err
+		}
+		loc = l
 	}
 
-	if locTag := structField.Tag.Get("time_location"); locTag != "" {
-		loc, err := time.LoadLocation(locTag)
+	if field.timeFormat != "" {
+		t, err := time.ParseInLocation(field.timeFormat, value, loc)
 		if err != nil {
 			return err
 		}
-		l = loc
+		target.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if len(b.TimeFormats) > 0 {
+		var err error
+		for _, format := range b.TimeFormats {
+			var t time.Time
+			if t, err = time.ParseInLocation(format, value, loc); err == nil {
+				target.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		if t, ok := parseUnixTimestamp(value); ok {
+			target.Set(reflect.ValueOf(t.In(loc)))
+			return nil
+		}
+		return err
+	}
+
+	if t, ok := parseUnixTimestamp(value); ok {
+		target.Set(reflect.ValueOf(t.In(loc)))
+		return nil
+	}
+
+	var err error
+	for _, format := range defaultTimeFormats {
+		var t time.Time
+		if t, err = time.ParseInLocation(format, value, loc); err == nil {
+			target.Set(reflect.ValueOf(t))
+			return nil
+		}
 	}
+	return err
+}
 
-	t, err := time.ParseInLocation(timeFormat, value, l)
+// parseUnixTimestamp recognizes a purely numeric value as a Unix timestamp, auto-detecting
+// second vs. millisecond precision from its magnitude.
+func parseUnixTimestamp(value string) (time.Time, bool) {
+	n, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		return err
+		return time.Time{}, false
 	}
+	const unixMilliThreshold = 1e12 // seconds since epoch won't reach this until the year 33658
+	if n > unixMilliThreshold || n < -unixMilliThreshold {
+		return time.Unix(n/1e3, (n%1e3)*int64(time.Millisecond)), true
+	}
+	return time.Unix(n, 0), true
+}
 
-	field.Set(reflect.ValueOf(t))
+// setDurationField binds value into a time.Duration field using time.ParseDuration, so values
+// like "1h30m" or "500ms" are accepted in addition to a plain count of nanoseconds.
+func setDurationField(value string, field reflect.Value) error {
+	if value == "" {
+		field.SetInt(0)
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		n, intErr := strconv.ParseInt(value, 10, 64)
+		if intErr != nil {
+			return err
+		}
+		d = time.Duration(n)
+	}
+	field.SetInt(int64(d))
 	return nil
 }
 
@@ -0,0 +1,554 @@
+package echo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bindPrecedenceTestStruct struct {
+	ID    int    `param:"id" query:"id"`
+	Name  string `query:"name"`
+	Token string `header:"X-Token"`
+}
+
+func TestDefaultBinder_Bind_GetPathAndQuery(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/users/1?name=jon", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	s := new(bindPrecedenceTestStruct)
+	err := c.Bind(s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s.ID)
+	assert.Equal(t, "jon", s.Name)
+}
+
+func TestDefaultBinder_Bind_PostBodyPathAndHeader(t *testing.T) {
+	e := New()
+	body := `{"id":2,"name":"joe"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/2", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	s := new(bindPrecedenceTestStruct)
+	err := c.Bind(s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.ID)
+	assert.Equal(t, "joe", s.Name)
+	assert.Equal(t, "secret", s.Token)
+}
+
+type bindCookieTestStruct struct {
+	SessionID string `cookie:"session_id"`
+}
+
+func TestDefaultBinder_Bind_Cookie(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "abc123"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	s := new(bindCookieTestStruct)
+	err := c.Bind(s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", s.SessionID)
+}
+
+func TestDefaultBinder_Bind_BodyOverridesPathForSameField(t *testing.T) {
+	e := New()
+	body := `{"id":99}`
+	req := httptest.NewRequest(http.MethodPost, "/users/2", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	s := new(bindPrecedenceTestStruct)
+	err := c.Bind(s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 99, s.ID)
+}
+
+type bindJSONOnlyTestStruct struct {
+	ID   int    `param:"id"`
+	Name string `json:"name"`
+}
+
+// TestDefaultBinder_Bind_JSONOnlyFieldNotLeakedFromPathParam is the regression test for a field
+// pinned to the body via a plain `json` tag: a same-named path param must not populate it when
+// the body omits that key.
+func TestDefaultBinder_Bind_JSONOnlyFieldNotLeakedFromPathParam(t *testing.T) {
+	e := New()
+	body := `{"id":2}`
+	req := httptest.NewRequest(http.MethodPost, "/users/2", strings.NewReader(body))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "Name")
+	c.SetParamValues("2", "leaked")
+
+	s := new(bindJSONOnlyTestStruct)
+	err := c.Bind(s)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.ID)
+	assert.Equal(t, "", s.Name)
+}
+
+// stubValidator is a minimal Validator used to drive BindAndValidate's error-wrapping behavior
+// without depending on a third-party validation library.
+type stubValidator struct {
+	err error
+}
+
+func (v *stubValidator) Validate(i interface{}) error {
+	return v.err
+}
+
+func TestDefaultBinder_BindAndValidate_Success(t *testing.T) {
+	e := New()
+	e.Validator = &stubValidator{}
+	req := httptest.NewRequest(http.MethodGet, "/users/1?name=jon", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	b := &DefaultBinder{}
+	s := new(bindPrecedenceTestStruct)
+	err := b.BindAndValidate(s, c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s.ID)
+}
+
+func TestDefaultBinder_BindAndValidate_WrapsPlainValidatorError(t *testing.T) {
+	e := New()
+	e.Validator = &stubValidator{err: errors.New("name is required")}
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	b := &DefaultBinder{}
+	s := new(bindPrecedenceTestStruct)
+	err := b.BindAndValidate(s, c)
+
+	httpErr, ok := err.(*HTTPError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestDefaultBinder_BindAndValidate_PassesThroughHTTPError(t *testing.T) {
+	e := New()
+	original := NewHTTPError(http.StatusTeapot, "nope")
+	e.Validator = &stubValidator{err: original}
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	b := &DefaultBinder{}
+	s := new(bindPrecedenceTestStruct)
+	err := b.BindAndValidate(s, c)
+
+	assert.Equal(t, original, err)
+}
+
+func TestDefaultBinder_BindAndValidate_PassesThroughBindingError(t *testing.T) {
+	e := New()
+	original := NewBindingError("name", []string{""}, "name is required", nil)
+	e.Validator = &stubValidator{err: original}
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	b := &DefaultBinder{}
+	s := new(bindPrecedenceTestStruct)
+	err := b.BindAndValidate(s, c)
+
+	assert.Equal(t, original, err)
+	bindingErr, ok := err.(*BindingError)
+	assert.True(t, ok)
+	assert.Equal(t, "name", bindingErr.Field)
+}
+
+func TestDefaultBinder_BindAndValidate_NoValidatorRegistered(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	b := &DefaultBinder{}
+	s := new(bindPrecedenceTestStruct)
+	err := b.BindAndValidate(s, c)
+
+	assert.Error(t, err)
+}
+
+type bindStructSliceItem struct {
+	Name string `form:"name"`
+}
+
+type bindStructSliceTarget struct {
+	Items []bindStructSliceItem
+}
+
+func TestDefaultBinder_bindStructSliceField_RejectsNegativeIndex(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindStructSliceTarget)
+	err := b.bindData(tgt, map[string][]string{
+		"Items[-1].name": {"a"},
+		"Items[2].name":  {"b"},
+	}, "form")
+
+	assert.Error(t, err)
+}
+
+func TestDefaultBinder_bindStructSliceField_RejectsOutOfRangeIndex(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindStructSliceTarget)
+	err := b.bindData(tgt, map[string][]string{
+		"Items[999999999].name": {"x"},
+	}, "form")
+
+	assert.Error(t, err)
+}
+
+func TestDefaultBinder_bindStructSliceField_AcceptsInRangeIndices(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindStructSliceTarget)
+	err := b.bindData(tgt, map[string][]string{
+		"Items[0].name": {"a"},
+		"Items[1].name": {"b"},
+	}, "form")
+
+	assert.NoError(t, err)
+	assert.Len(t, tgt.Items, 2)
+	assert.Equal(t, "a", tgt.Items[0].Name)
+	assert.Equal(t, "b", tgt.Items[1].Name)
+}
+
+func TestDefaultBinder_bindStructSliceField_CaseInsensitivePrefix(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindStructSliceTarget)
+	err := b.bindData(tgt, map[string][]string{"items[0].name": {"a"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Len(t, tgt.Items, 1)
+	assert.Equal(t, "a", tgt.Items[0].Name)
+}
+
+type bindMapTestStruct struct {
+	Meta map[string]string `form:"meta"`
+}
+
+func TestDefaultBinder_bindMapField_HappyPath(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindMapTestStruct)
+	err := b.bindData(tgt, map[string][]string{
+		"meta[a]": {"1"},
+		"meta[b]": {"2"},
+	}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, tgt.Meta)
+}
+
+func TestDefaultBinder_bindMapField_RejectsAmbiguousKey(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindMapTestStruct)
+	err := b.bindData(tgt, map[string][]string{"meta[]": {"x"}}, "form")
+
+	assert.Error(t, err)
+}
+
+func TestDefaultBinder_bindMapField_CaseInsensitivePrefix(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindMapTestStruct)
+	err := b.bindData(tgt, map[string][]string{"Meta[a]": {"1"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, tgt.Meta)
+}
+
+func TestDefaultBinder_bindMapField_RejectsNestedBracketKey(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindMapTestStruct)
+	err := b.bindData(tgt, map[string][]string{"meta[a][b]": {"x"}}, "form")
+
+	assert.Error(t, err)
+}
+
+
+// bindUnmarshalerSliceItem implements BindUnmarshaler via a pointer receiver, mirroring the
+// pre-chunk0-4 behavior that binds repeated `items=a&items=b` keys into a []struct field element
+// by element instead of through the bracketed `items[idx].field` syntax.
+type bindUnmarshalerSliceItem struct {
+	V string
+}
+
+func (i *bindUnmarshalerSliceItem) UnmarshalParam(s string) error {
+	i.V = s
+	return nil
+}
+
+type bindUnmarshalerSliceTarget struct {
+	Items []bindUnmarshalerSliceItem `form:"items"`
+}
+
+func TestDefaultBinder_bindData_SliceOfBindUnmarshalerStruct(t *testing.T) {
+	b := &DefaultBinder{}
+
+	tgt := new(bindUnmarshalerSliceTarget)
+	err := b.bindData(tgt, map[string][]string{"items": {"a", "b"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bindUnmarshalerSliceItem{{V: "a"}, {V: "b"}}, tgt.Items)
+}
+
+// registeredSliceItem has neither a BindUnmarshaler nor a time-like shape, so it only binds via a
+// type registered through RegisterType.
+type registeredSliceItem struct {
+	V string
+}
+
+type registeredSliceTarget struct {
+	Items []registeredSliceItem `form:"items"`
+}
+
+func TestDefaultBinder_bindData_SliceOfRegisteredType(t *testing.T) {
+	b := &DefaultBinder{}
+	b.RegisterType(reflect.TypeOf(registeredSliceItem{}), func(values []string, target reflect.Value) error {
+		target.Set(reflect.ValueOf(registeredSliceItem{V: values[0]}))
+		return nil
+	})
+
+	tgt := new(registeredSliceTarget)
+	err := b.bindData(tgt, map[string][]string{"items": {"a", "b"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []registeredSliceItem{{V: "a"}, {V: "b"}}, tgt.Items)
+}
+
+// rawBytes has Kind Slice, like net.IP, which is exactly the shape that used to bypass the
+// custom type registry entirely: it was classified as a generic scalar slice before the registry
+// was ever consulted.
+type rawBytes []byte
+
+func TestDefaultBinder_RegisterType_SliceKindField(t *testing.T) {
+	type target struct {
+		Raw rawBytes `form:"raw"`
+	}
+
+	b := &DefaultBinder{}
+	b.RegisterType(reflect.TypeOf(rawBytes{}), func(values []string, target reflect.Value) error {
+		target.SetBytes([]byte(values[0]))
+		return nil
+	})
+
+	tgt := new(target)
+	err := b.bindData(tgt, map[string][]string{"raw": {"hello"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, rawBytes("hello"), tgt.Raw)
+}
+
+// customPoint has Kind Struct and no BindUnmarshaler, the other shape the registry used to never
+// reach.
+type customPoint struct{ X, Y int }
+
+type bindParityTestStruct struct {
+	UserID  int       `query:"UserId"`
+	Created time.Time `query:"created"`
+}
+
+// TestDefaultBinder_bindData_CaseInsensitiveFallbackAndTimeParsing is a behavior-parity check for
+// the decoderPlan cache introduced in bindData: it binds the same struct type twice, once via an
+// exact-case query key and once via a differently-cased key that only matches through the
+// case-insensitive fallback, and checks that a cached plan still resolves time.Time fields using
+// the RFC3339 default.
+func TestDefaultBinder_bindData_CaseInsensitiveFallbackAndTimeParsing(t *testing.T) {
+	b := &DefaultBinder{}
+
+	exact := new(bindParityTestStruct)
+	err := b.bindData(exact, map[string][]string{
+		"UserId":  {"7"},
+		"created": {"2023-01-02T03:04:05Z"},
+	}, "query")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, exact.UserID)
+	assert.True(t, exact.Created.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	lowerCased := new(bindParityTestStruct)
+	err = b.bindData(lowerCased, map[string][]string{
+		"userid":  {"9"},
+		"created": {"2023-01-02T03:04:05Z"},
+	}, "query")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, lowerCased.UserID)
+	assert.True(t, lowerCased.Created.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+type bindTimeTestStruct struct {
+	Default  time.Time  `query:"default"`
+	Custom   time.Time  `query:"custom" time_format:"2006/01/02"`
+	Located  time.Time  `query:"located" time_format:"2006-01-02 15:04:05" time_location:"America/New_York"`
+	UTC      time.Time  `query:"utc" time_utc:"true"`
+	Optional *time.Time `query:"optional"`
+}
+
+func TestDefaultBinder_setTimeField_RFC3339Default(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"default": {"2023-01-02T03:04:05Z"}}, "query")
+
+	assert.NoError(t, err)
+	assert.True(t, tgt.Default.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+func TestDefaultBinder_setTimeField_UnixSecondsAndMillis(t *testing.T) {
+	b := &DefaultBinder{}
+
+	secs := new(bindTimeTestStruct)
+	err := b.bindData(secs, map[string][]string{"default": {"1672626245"}}, "query")
+	assert.NoError(t, err)
+	assert.True(t, secs.Default.Equal(time.Unix(1672626245, 0)))
+
+	millis := new(bindTimeTestStruct)
+	err = b.bindData(millis, map[string][]string{"default": {"1672626245123"}}, "query")
+	assert.NoError(t, err)
+	assert.True(t, millis.Default.Equal(time.Unix(1672626245, 123*int64(time.Millisecond))))
+}
+
+func TestDefaultBinder_setTimeField_FieldTagFormat(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"custom": {"2023/01/02"}}, "query")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2023, tgt.Custom.Year())
+	assert.Equal(t, time.January, tgt.Custom.Month())
+	assert.Equal(t, 2, tgt.Custom.Day())
+}
+
+func TestDefaultBinder_setTimeField_TimeLocationTag(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"located": {"2023-01-02 03:04:05"}}, "query")
+	assert.NoError(t, err)
+
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	assert.True(t, tgt.Located.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, ny)))
+}
+
+func TestDefaultBinder_setTimeField_TimeUTCTag(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"utc": {"2023-01-02T03:04:05Z"}}, "query")
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, tgt.UTC.Location())
+}
+
+func TestDefaultBinder_setTimeField_TimePtrField(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"optional": {"2023-01-02T03:04:05Z"}}, "query")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, tgt.Optional)
+	assert.True(t, tgt.Optional.Equal(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+// TestDefaultBinder_setTimeField_TimePtrFieldLeftNilOnEmptyValue is the regression test for the
+// *time.Time field keeping the pointer-means-optional contract: a present-but-empty value must
+// not allocate the pointer just to hold the zero time.
+func TestDefaultBinder_setTimeField_TimePtrFieldLeftNilOnEmptyValue(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"optional": {""}}, "query")
+
+	assert.NoError(t, err)
+	assert.Nil(t, tgt.Optional)
+}
+
+// TestDefaultBinder_setTimeField_ConfiguredFormatBeforeUnixDetection is the regression test for
+// the ordering fix: a purely-numeric value matching a configured TimeFormats entry must not be
+// shadowed by the Unix-timestamp auto-detect heuristic.
+func TestDefaultBinder_setTimeField_ConfiguredFormatBeforeUnixDetection(t *testing.T) {
+	b := &DefaultBinder{TimeFormats: []string{"20060102"}}
+	tgt := new(bindTimeTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"default": {"20230102"}}, "query")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2023, tgt.Default.Year())
+	assert.Equal(t, time.January, tgt.Default.Month())
+	assert.Equal(t, 2, tgt.Default.Day())
+}
+
+type bindDurationTestStruct struct {
+	Timeout time.Duration `query:"timeout"`
+}
+
+func TestDefaultBinder_setDurationField(t *testing.T) {
+	b := &DefaultBinder{}
+	tgt := new(bindDurationTestStruct)
+
+	err := b.bindData(tgt, map[string][]string{"timeout": {"1h30m"}}, "query")
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+30*time.Minute, tgt.Timeout)
+}
+
+func TestDefaultBinder_RegisterType_StructKindField(t *testing.T) {
+	type target struct {
+		P customPoint `form:"p"`
+	}
+
+	b := &DefaultBinder{}
+	b.RegisterType(reflect.TypeOf(customPoint{}), func(values []string, target reflect.Value) error {
+		target.Set(reflect.ValueOf(customPoint{X: 1, Y: 2}))
+		return nil
+	})
+
+	tgt := new(target)
+	err := b.bindData(tgt, map[string][]string{"p": {"1,2"}}, "form")
+
+	assert.NoError(t, err)
+	assert.Equal(t, customPoint{X: 1, Y: 2}, tgt.P)
+}